@@ -0,0 +1,172 @@
+module "secrets-manager-7" {
+
+  #source = "lgallard/secrets-manager/aws"
+  source = "../../"
+
+  secrets = {
+    complete-plain = {
+      description             = "My plain text secret"
+      recovery_window_in_days = 0
+      secret_string           = "This is an example"
+      kms_key_id              = aws_kms_key.secrets.arn
+      policy                  = <<POLICY
+				{
+					"Version": "2012-10-17",
+					"Statement": [
+						{
+							"Sid": "EnableAllPermissions",
+							"Effect": "Allow",
+							"Principal": {
+								"AWS": "arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:root"
+							},
+							"Action": "secretsmanager:GetSecretValue",
+							"Resource": "*"
+						}
+					]
+				}
+				POLICY
+    },
+    complete-key-value = {
+      description = "This is a key/value secret"
+      secret_key_value = {
+        username = "user"
+        password = "topsecret"
+      }
+      kms_key_id = aws_kms_key.secrets.arn
+      replica_regions = {
+        us-west-2 = {}
+      }
+      force_overwrite_replica_secret = true
+      tags = {
+        app = "web"
+      }
+      recovery_window_in_days = 0
+    },
+    complete-binary = {
+      description             = "This is a binary secret"
+      secret_binary           = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQDt4TcI58h4G0wR+GcDY+0VJR10JNvG92jEKGaKxeMaOkfsXflVGsYXbfVBBCG/n3uHtTse7baYLB6LWQAuYWL1SHJVhhTQ7pPiocFWibAvJlVo1l7qJEDu2OxKpWEleCE+p3ufNXAy7v5UFO7EOnj0Zg6R3F/MiAWbQnaEHcYzNtogyC24YBecBLrBXZNi1g0AN1hM9k+3XvWUYTf9vPv8LIWnqo7y4Q2iEGWWurf37YFl1LzX4mG/Co+Vfe5TlZSe2YPMYWlw0ZKaKvwzInRR6dPMAflo3ABzlduiIbSdp110uGqB8i2M8eGXNDxR7Ni4nnLWnT9r1cpWhXWP6pAG4Xg8+x7+PIg/pgjgJNmsURw+jPD6+hkCw2Vz16EIgkC2b7lj0V6J4LncUoRzU/1sAzCQ4tspy3SKBUinYoxbDvXleF66FHEjfparnvNwfslBx0IJjG2uRwuX6zrsNIsGF1stEjz+eyAOtFV4/wRjRcCNDZvl1ODzIvwf8pAWddE= lgallard@server1"
+      recovery_window_in_days = 0
+    },
+  }
+
+  rotate_secrets = {
+    complete-rotate = {
+      description             = "This is a secret to be rotated by a lambda"
+      secret_string           = "This is an example"
+      kms_key_id              = aws_kms_key.secrets.arn
+      rotation_lambda_arn     = aws_lambda_function.rotate_secret.arn
+      recovery_window_in_days = 0
+    },
+  }
+
+  tags = {
+    Owner       = "DevOps team"
+    Environment = "dev"
+    Terraform   = true
+  }
+
+  depends_on = [
+    aws_lambda_permission.allow_secret_manager_call_Lambda,
+    aws_iam_role_policy.rotate_secret,
+  ]
+}
+
+data "aws_caller_identity" "current" {}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+# Customer managed key to encrypt the secrets
+resource "aws_kms_key" "secrets" {
+  description             = "CMK for the complete secrets manager example"
+  deletion_window_in_days = 7
+}
+
+# Lambda to rotate secrets
+# AWS templates available here https://github.com/aws-samples/aws-secrets-manager-rotation-lambdas
+resource "aws_iam_role" "rotate_secret" {
+  name_prefix = "secrets-manager-rotation-"
+
+  assume_role_policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+POLICY
+}
+
+resource "aws_iam_role_policy_attachment" "rotate_secret_logs" {
+  role       = aws_iam_role.rotate_secret.name
+  policy_arn = "arn:${data.aws_partition.current.partition}:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+# The rotation starts as soon as it is configured, so the role must already be
+# able to read and write the rotated secret and use the CMK that encrypts it.
+resource "aws_iam_role_policy" "rotate_secret" {
+  name = "secrets-manager-rotation"
+  role = aws_iam_role.rotate_secret.id
+
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "secretsmanager:DescribeSecret",
+        "secretsmanager:GetSecretValue",
+        "secretsmanager:PutSecretValue",
+        "secretsmanager:UpdateSecretVersionStage"
+      ],
+      "Resource": "arn:${data.aws_partition.current.partition}:secretsmanager:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:secret:complete-rotate-??????"
+    },
+    {
+      "Effect": "Allow",
+      "Action": "secretsmanager:GetRandomPassword",
+      "Resource": "*"
+    },
+    {
+      "Effect": "Allow",
+      "Action": [
+        "kms:Decrypt",
+        "kms:GenerateDataKey"
+      ],
+      "Resource": "${aws_kms_key.secrets.arn}"
+    }
+  ]
+}
+POLICY
+}
+
+resource "aws_lambda_function" "rotate_secret" {
+  filename         = "${path.module}/../rotation/secrets_manager_rotation.zip"
+  function_name    = aws_iam_role.rotate_secret.name
+  role             = aws_iam_role.rotate_secret.arn
+  handler          = "lambda_function.lambda_handler"
+  runtime          = "python3.12"
+  source_code_hash = filebase64sha256("${path.module}/../rotation/secrets_manager_rotation.zip")
+
+  environment {
+    variables = {
+      SECRETS_MANAGER_ENDPOINT = "https://secretsmanager.${data.aws_region.current.name}.amazonaws.com"
+    }
+  }
+
+  depends_on = [aws_iam_role_policy_attachment.rotate_secret_logs]
+}
+
+resource "aws_lambda_permission" "allow_secret_manager_call_Lambda" {
+  function_name = aws_lambda_function.rotate_secret.function_name
+  statement_id  = "AllowExecutionSecretManager"
+  action        = "lambda:InvokeFunction"
+  principal     = "secretsmanager.amazonaws.com"
+}
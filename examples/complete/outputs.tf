@@ -0,0 +1,19 @@
+output "secret_arns" {
+  description = "Secrets arns map"
+  value       = module.secrets-manager-7.secret_arns
+}
+
+output "rotate_secret_arns" {
+  description = "Rotate secret arns map"
+  value       = module.secrets-manager-7.rotate_secret_arns
+}
+
+output "kms_key_arn" {
+  description = "ARN of the CMK encrypting the secrets"
+  value       = aws_kms_key.secrets.arn
+}
+
+output "rotation_lambda_arn" {
+  description = "ARN of the Lambda rotating the secrets"
+  value       = aws_lambda_function.rotate_secret.arn
+}
@@ -0,0 +1,4 @@
+provider "aws" {
+  profile = "default"
+  region  = "us-east-1"
+}